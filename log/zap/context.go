@@ -0,0 +1,36 @@
+package zaplog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// With returns the global logger with fields permanently attached, for
+// request-scoped values (request_id, user_id, tenant) that would otherwise
+// need to be passed at every call site.
+func With(fields ...zap.Field) *zap.Logger {
+	return logger.With(fields...)
+}
+
+// WithSugar is With for the sugared logger.
+func WithSugar(keysAndValues ...interface{}) *zap.SugaredLogger {
+	return sugaredLogger.With(keysAndValues...)
+}
+
+type loggerCtxKey struct{}
+
+// NewContext returns a copy of ctx carrying l, retrievable later with
+// FromContext.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns the logger stashed in ctx by NewContext, or the
+// global logger if ctx carries none.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return logger
+}
@@ -0,0 +1,23 @@
+package zaplog
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestFromContextReturnsGlobalLoggerWhenNoneStashed(t *testing.T) {
+	if got := FromContext(context.Background()); got != logger {
+		t.Errorf("FromContext() = %p, want global logger %p", got, logger)
+	}
+}
+
+func TestNewContextRoundTripsLogger(t *testing.T) {
+	l := With(zap.String("k", "v"))
+	ctx := NewContext(context.Background(), l)
+
+	if got := FromContext(ctx); got != l {
+		t.Errorf("FromContext() = %p, want %p", got, l)
+	}
+}
@@ -0,0 +1,34 @@
+package zaplog
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGRPCLoggerV2VFollowsAtomicLevel(t *testing.T) {
+	defer func(lvl zapcore.Level) { atomicLevel.SetLevel(lvl) }(atomicLevel.Level())
+
+	g := &grpcLoggerV2{logger: logger}
+
+	atomicLevel.SetLevel(zapcore.DebugLevel)
+	if !g.V(0) {
+		t.Error("V(0) = false at DebugLevel, want true")
+	}
+	if !g.V(2) {
+		t.Error("V(2) = false at DebugLevel, want true")
+	}
+
+	atomicLevel.SetLevel(zapcore.InfoLevel)
+	if !g.V(0) {
+		t.Error("V(0) = false at InfoLevel, want true")
+	}
+	if g.V(2) {
+		t.Error("V(2) = true at InfoLevel, want false")
+	}
+
+	atomicLevel.SetLevel(zapcore.ErrorLevel)
+	if g.V(0) {
+		t.Error("V(0) = true at ErrorLevel, want false")
+	}
+}
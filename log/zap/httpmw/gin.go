@@ -0,0 +1,41 @@
+package httpmw
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	zaplog "github.com/fengberlin/libgo/log/zap"
+)
+
+// Gin returns a gin.HandlerFunc equivalent to Middleware: it gives every
+// request a child logger carrying request_id/method/path fields, reachable
+// from later handlers through zaplog.FromContext(c.Request.Context()), and
+// logs one access-log record on response with method, path, status,
+// latency, and bytes written.
+func Gin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		reqLogger := zaplog.With(
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+		)
+		c.Request = c.Request.WithContext(zaplog.NewContext(c.Request.Context(), reqLogger))
+
+		start := time.Now()
+		c.Next()
+
+		reqLogger.Info("access",
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", c.Writer.Size()),
+		)
+	}
+}
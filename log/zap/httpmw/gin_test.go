@@ -0,0 +1,42 @@
+package httpmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	zaplog "github.com/fengberlin/libgo/log/zap"
+)
+
+func TestGinSetsRequestIDHeaderAndContextLoggerAndCallsNext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Gin())
+
+	var loggerInContext bool
+	var nextCalled bool
+	router.GET("/widgets", func(c *gin.Context) {
+		nextCalled = true
+		loggerInContext = zaplog.FromContext(c.Request.Context()) != nil
+		c.Status(http.StatusTeapot)
+	})
+
+	req := httptest.NewRequest("GET", "/widgets", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if !nextCalled {
+		t.Fatal("handler after Gin() middleware was never called")
+	}
+	if !loggerInContext {
+		t.Error("handler's context carried no logger")
+	}
+	if got := rec.Header().Get(requestIDHeader); got == "" {
+		t.Error("response missing request ID header")
+	}
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+}
@@ -0,0 +1,69 @@
+package httpmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zaplog "github.com/fengberlin/libgo/log/zap"
+)
+
+func init() {
+	zaplog.InitLogger()
+}
+
+func TestMiddlewareSetsRequestIDHeaderAndContextLogger(t *testing.T) {
+	var loggerInContext bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loggerInContext = zaplog.FromContext(r.Context()) != nil
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("hi"))
+	})
+
+	req := httptest.NewRequest("GET", "/widgets", nil)
+	rec := httptest.NewRecorder()
+	Middleware(next).ServeHTTP(rec, req)
+
+	if !loggerInContext {
+		t.Error("handler's context carried no logger")
+	}
+	if got := rec.Header().Get(requestIDHeader); got == "" {
+		t.Error("response missing request ID header")
+	}
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+}
+
+func TestMiddlewarePreservesIncomingRequestID(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest("GET", "/widgets", nil)
+	req.Header.Set(requestIDHeader, "fixed-id")
+	rec := httptest.NewRecorder()
+	Middleware(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(requestIDHeader); got != "fixed-id" {
+		t.Errorf("request ID = %q, want %q (should be preserved, not regenerated)", got, "fixed-id")
+	}
+}
+
+func TestStatusRecorderCapturesStatusAndBytes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sr := &statusRecorder{ResponseWriter: rec, status: http.StatusOK}
+
+	sr.WriteHeader(http.StatusCreated)
+	n, err := sr.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("Write() = %d, want 5", n)
+	}
+	if sr.status != http.StatusCreated {
+		t.Errorf("status = %d, want %d", sr.status, http.StatusCreated)
+	}
+	if sr.bytes != 5 {
+		t.Errorf("bytes = %d, want 5", sr.bytes)
+	}
+}
@@ -0,0 +1,46 @@
+package zaplog
+
+import (
+	"os"
+
+	"github.com/Shopify/sarama"
+)
+
+// kafkaSinkWriter ships batches of already-encoded JSON records to a Kafka
+// topic, keyed by KUBE_PODNAME so records from one pod land on the same
+// partition.
+type kafkaSinkWriter struct {
+	topic    string
+	podName  string
+	producer sarama.SyncProducer
+}
+
+func newKafkaSinkWriter(topic string, brokers []string) (*kafkaSinkWriter, error) {
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaSinkWriter{
+		topic:    topic,
+		podName:  os.Getenv("KUBE_PODNAME"),
+		producer: producer,
+	}, nil
+}
+
+func (w *kafkaSinkWriter) WriteBatch(records []sinkRecord) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(records))
+	for _, record := range records {
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: w.topic,
+			Key:   sarama.StringEncoder(w.podName),
+			Value: sarama.ByteEncoder(record.Data),
+		})
+	}
+	return w.producer.SendMessages(msgs)
+}
+
+func (w *kafkaSinkWriter) Close() error {
+	return w.producer.Close()
+}
@@ -13,6 +13,13 @@ const (
 
 type levelAndAbove zapcore.Level
 
+// Enabled implements zapcore.LevelEnabler, enabling lvl and every level
+// above it. Sink cores use this form directly instead of splitting into
+// one core per level, which EnableLevels is for.
+func (l levelAndAbove) Enabled(lvl zapcore.Level) bool {
+	return lvl >= zapcore.Level(l)
+}
+
 func (l levelAndAbove) EnableLevels() map[zapcore.Level]zap.LevelEnablerFunc {
 	levels := getLevels(zapcore.Level(l))
 	m := make(map[zapcore.Level]zap.LevelEnablerFunc, len(levels))
@@ -18,6 +18,18 @@ var (
 	sugaredLogger *zap.SugaredLogger
 	once          sync.Once
 	atomicLevel   zap.AtomicLevel
+
+	// otelLogger is returned by WithContext/Ctx for direct chaining (e.g.
+	// zaplog.Ctx(ctx).Info(msg)), so it needs no extra caller skip beyond
+	// logger's own. otelLoggerForHelpers is the same logger with an extra
+	// AddCallerSkip(1), the same trick otelzap uses, and is used only by
+	// the DebugCtx/InfoCtx/ErrorCtx package-level helpers, which add one
+	// more call frame on top of that direct-chaining path.
+	otelLogger              *zap.Logger
+	otelLoggerForHelpers    *zap.Logger
+	traceCorrelationEnabled bool
+	otelMinLevel            zapcore.Level
+	otelErrorStatusLevel    zapcore.Level
 )
 
 const (
@@ -52,6 +64,17 @@ func NewEncoderConfig() zapcore.EncoderConfig {
 // write log to os.stderr. In production, you have better
 // specify the serviceName(use WithServiceName).
 func NewLogger(opts ...Option) (*zap.Logger, zap.AtomicLevel) {
+	newLogger, atomicLevel, _ := newLoggerWithOptions(opts...)
+	return newLogger, atomicLevel
+}
+
+// newLoggerWithOptions does the work behind NewLogger and also returns the
+// resolved logOptions, so callers that need fields off it (InitLogger wants
+// traceCorrelation/otelMinLevel/otelErrorStatusLevel) don't have to apply
+// opts a second time — opts can carry side effects (WithKafkaSink dials a
+// broker inside apply()), so re-applying them double-runs those side
+// effects.
+func newLoggerWithOptions(opts ...Option) (*zap.Logger, zap.AtomicLevel, logOptions) {
 	logOpts := defaultLogOptions
 	for i := 0; i < len(opts); i++ {
 		opts[i].apply(&logOpts)
@@ -89,11 +112,24 @@ func NewLogger(opts ...Option) (*zap.Logger, zap.AtomicLevel) {
 		}
 	}
 
+	logCores = append(logCores, logOpts.extraCores...)
+	for _, buildSinkCore := range logOpts.sinkFactories {
+		logCores = append(logCores, buildSinkCore(levelAndAbove(atomicLevel.Level())))
+	}
+
+	tee := zapcore.NewTee(logCores...)
+	if logOpts.sampling.Tick > 0 || len(logOpts.perLevelSampling) > 0 {
+		tee = wrapWithSampling(tee, logOpts.sampling, logOpts.perLevelSampling)
+	}
+	if logOpts.rateLimitPerSecond > 0 {
+		tee = newRateLimitCore(tee, logOpts.rateLimitPerSecond, logOpts.rateLimitBurst, defaultRateLimitReportTick)
+	}
+
 	var newLogger *zap.Logger
 	if logOpts.development {
-		newLogger = zap.New(zapcore.NewTee(logCores...), zap.Development())
+		newLogger = zap.New(tee, zap.Development())
 	} else {
-		newLogger = zap.New(zapcore.NewTee(logCores...))
+		newLogger = zap.New(tee)
 	}
 
 	if logOpts.addCaller {
@@ -114,7 +150,7 @@ func NewLogger(opts ...Option) (*zap.Logger, zap.AtomicLevel) {
 	if logOpts.errorOutput != nil {
 		newLogger = newLogger.WithOptions(zap.ErrorOutput(logOpts.errorOutput))
 	}
-	return newLogger, atomicLevel
+	return newLogger, atomicLevel, logOpts
 }
 
 // NewSugaredLogger new a sugar logger for using method such as
@@ -128,8 +164,21 @@ func NewSugaredLogger(opts ...Option) (*zap.SugaredLogger, zap.AtomicLevel) {
 // InitLogger initialize a global logger and sugar logger to use
 func InitLogger(opts ...Option) {
 	once.Do(func() {
-		logger, atomicLevel = NewLogger(opts...)
+		var logOpts logOptions
+		logger, atomicLevel, logOpts = newLoggerWithOptions(opts...)
 		sugaredLogger = logger.Sugar()
+
+		traceCorrelationEnabled = logOpts.traceCorrelation
+		otelMinLevel = logOpts.otelMinLevel
+		otelErrorStatusLevel = logOpts.otelErrorStatusLevel
+		// otelLogger is for WithContext/Ctx, which is returned to the
+		// caller to chain directly (e.g. zaplog.Ctx(ctx).Info(msg)) with
+		// no extra frame, so it must not carry the DebugCtx/InfoCtx/
+		// ErrorCtx helpers' AddCallerSkip(1). otelLoggerForHelpers is the
+		// one those package-level helpers use instead, with the skip
+		// they need to still report the user's call site.
+		otelLogger = logger
+		otelLoggerForHelpers = logger.WithOptions(zap.AddCallerSkip(1))
 	})
 }
 
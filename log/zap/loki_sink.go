@@ -0,0 +1,84 @@
+package zaplog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// lokiSinkWriter batches records into Loki's /loki/api/v1/push streams
+// format, one stream per level, labeled with serviceName and podName.
+type lokiSinkWriter struct {
+	pushURL     string
+	serviceName string
+	podName     string
+	client      *http.Client
+}
+
+func newLokiSinkWriter(pushURL, serviceName, podName string) *lokiSinkWriter {
+	return &lokiSinkWriter{
+		pushURL:     pushURL,
+		serviceName: serviceName,
+		podName:     podName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (w *lokiSinkWriter) WriteBatch(records []sinkRecord) error {
+	streams := make(map[zapcore.Level]*lokiStream, len(records))
+	order := make([]zapcore.Level, 0, len(records))
+	for _, record := range records {
+		stream, ok := streams[record.Level]
+		if !ok {
+			stream = &lokiStream{
+				Stream: map[string]string{
+					"service_name": w.serviceName,
+					"pod_name":     w.podName,
+					"level":        record.Level.String(),
+				},
+			}
+			streams[record.Level] = stream
+			order = append(order, record.Level)
+		}
+		ts := strconv.FormatInt(record.Time.UnixNano(), 10)
+		stream.Values = append(stream.Values, [2]string{ts, string(record.Data)})
+	}
+
+	req := lokiPushRequest{Streams: make([]lokiStream, 0, len(order))}
+	for _, level := range order {
+		req.Streams = append(req.Streams, *streams[level])
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.pushURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("zaplog: loki push returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *lokiSinkWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
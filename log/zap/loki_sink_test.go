@@ -0,0 +1,73 @@
+package zaplog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLokiSinkWriteBatchGroupsByLevelAndKeepsPerRecordTimestamp(t *testing.T) {
+	var got lokiPushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode push body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := newLokiSinkWriter(server.URL, "svc", "pod-1")
+
+	infoTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	errTime := infoTime.Add(time.Minute)
+	records := []sinkRecord{
+		{Level: zapcore.InfoLevel, Time: infoTime, Data: []byte(`{"msg":"one"}`)},
+		{Level: zapcore.ErrorLevel, Time: errTime, Data: []byte(`{"msg":"two"}`)},
+		{Level: zapcore.InfoLevel, Time: infoTime.Add(time.Second), Data: []byte(`{"msg":"three"}`)},
+	}
+
+	if err := w.WriteBatch(records); err != nil {
+		t.Fatalf("WriteBatch: %v", err)
+	}
+
+	if len(got.Streams) != 2 {
+		t.Fatalf("streams = %d, want 2 (one per level)", len(got.Streams))
+	}
+
+	var infoStream, errStream *lokiStream
+	for i := range got.Streams {
+		switch got.Streams[i].Stream["level"] {
+		case "info":
+			infoStream = &got.Streams[i]
+		case "error":
+			errStream = &got.Streams[i]
+		}
+	}
+	if infoStream == nil || errStream == nil {
+		t.Fatalf("missing level-labeled stream, got streams: %+v", got.Streams)
+	}
+	if infoStream.Stream["service_name"] != "svc" || infoStream.Stream["pod_name"] != "pod-1" {
+		t.Errorf("info stream labels = %+v, want service_name=svc pod_name=pod-1", infoStream.Stream)
+	}
+
+	if len(infoStream.Values) != 2 {
+		t.Fatalf("info stream values = %d, want 2", len(infoStream.Values))
+	}
+	if len(errStream.Values) != 1 {
+		t.Fatalf("error stream values = %d, want 1", len(errStream.Values))
+	}
+
+	wantInfoTS := strconv.FormatInt(infoTime.UnixNano(), 10)
+	if infoStream.Values[0][0] != wantInfoTS {
+		t.Errorf("first info record timestamp = %q, want %q (each record's own time, not the flush time)", infoStream.Values[0][0], wantInfoTS)
+	}
+	wantErrTS := strconv.FormatInt(errTime.UnixNano(), 10)
+	if errStream.Values[0][0] != wantErrTS {
+		t.Errorf("error record timestamp = %q, want %q", errStream.Values[0][0], wantErrTS)
+	}
+}
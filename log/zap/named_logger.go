@@ -0,0 +1,74 @@
+package zaplog
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// namedLogger pairs a registered subsystem logger with the atomic level
+// NewLogger handed back for it, so NamedAtomicLevelHandler can mutate it.
+type namedLogger struct {
+	logger *zap.Logger
+	level  zap.AtomicLevel
+}
+
+var (
+	namedLoggersMu sync.RWMutex
+	namedLoggers   = make(map[string]*namedLogger)
+)
+
+// NewNamedLogger creates a logger for a subsystem (e.g. "http", "db",
+// "worker") and registers it under name, so its level can later be queried
+// or changed at runtime through NamedAtomicLevelHandler, and looked back up
+// with GetLogger.
+func NewNamedLogger(name string, opts ...Option) (*zap.Logger, zap.AtomicLevel) {
+	newLogger, level := NewLogger(opts...)
+	namedLoggersMu.Lock()
+	namedLoggers[name] = &namedLogger{logger: newLogger, level: level}
+	namedLoggersMu.Unlock()
+	return newLogger, level
+}
+
+// GetLogger looks up a logger previously registered with NewNamedLogger. It
+// returns nil if name is not registered.
+func GetLogger(name string) *zap.Logger {
+	namedLoggersMu.RLock()
+	defer namedLoggersMu.RUnlock()
+	nl, ok := namedLoggers[name]
+	if !ok {
+		return nil
+	}
+	return nl.logger
+}
+
+// NamedAtomicLevelHandler serves GET and PUT /log/level?name=<name>, to
+// query or mutate the level of a subsystem logger registered with
+// NewNamedLogger, the same way AtomicLevelHandler does for the single
+// global logger — it delegates to the same zap.AtomicLevel.ServeHTTP, so it
+// gets the same form-urlencoded PUT support and JSON error body for free.
+func NamedAtomicLevelHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	namedLoggersMu.RLock()
+	nl, ok := namedLoggers[name]
+	namedLoggersMu.RUnlock()
+	if !ok {
+		http.Error(w, "zaplog: no logger registered under name "+name, http.StatusNotFound)
+		return
+	}
+	nl.level.ServeHTTP(w, r)
+}
+
+// ListLevels reports every logger registered with NewNamedLogger and its
+// current level as JSON: {"db":"info","http":"debug",...}.
+func ListLevels(w http.ResponseWriter, r *http.Request) {
+	namedLoggersMu.RLock()
+	levels := make(map[string]string, len(namedLoggers))
+	for name, nl := range namedLoggers {
+		levels[name] = nl.level.Level().String()
+	}
+	namedLoggersMu.RUnlock()
+	json.NewEncoder(w).Encode(levels)
+}
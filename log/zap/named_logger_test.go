@@ -0,0 +1,82 @@
+package zaplog
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewNamedLoggerRegistersAndIsLookedUp(t *testing.T) {
+	l, _ := NewNamedLogger("test-named-logger")
+	if got := GetLogger("test-named-logger"); got != l {
+		t.Errorf("GetLogger() = %p, want %p", got, l)
+	}
+}
+
+func TestGetLoggerUnknownNameReturnsNil(t *testing.T) {
+	if got := GetLogger("does-not-exist"); got != nil {
+		t.Errorf("GetLogger() = %v, want nil", got)
+	}
+}
+
+func TestNamedAtomicLevelHandlerUnknownName(t *testing.T) {
+	req := httptest.NewRequest("GET", "/log/level?name=does-not-exist", nil)
+	rec := httptest.NewRecorder()
+	NamedAtomicLevelHandler(rec, req)
+
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestNamedAtomicLevelHandlerGetAndPut(t *testing.T) {
+	NewNamedLogger("test-named-level-handler", WithLogLevel(zapcore.InfoLevel))
+
+	getReq := httptest.NewRequest("GET", "/log/level?name=test-named-level-handler", nil)
+	getRec := httptest.NewRecorder()
+	NamedAtomicLevelHandler(getRec, getReq)
+	if getRec.Code != 200 {
+		t.Fatalf("GET status = %d, want 200", getRec.Code)
+	}
+	var got struct {
+		Level string `json:"level"`
+	}
+	if err := json.Unmarshal(getRec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode GET body: %v", err)
+	}
+	if got.Level != "info" {
+		t.Fatalf("level = %q, want %q", got.Level, "info")
+	}
+
+	putReq := httptest.NewRequest("PUT", "/log/level?name=test-named-level-handler", strings.NewReader(`{"level":"error"}`))
+	putRec := httptest.NewRecorder()
+	NamedAtomicLevelHandler(putRec, putReq)
+	if putRec.Code != 200 {
+		t.Fatalf("PUT status = %d, want 200", putRec.Code)
+	}
+	if lvl := GetLogger("test-named-level-handler"); lvl == nil {
+		t.Fatal("logger vanished after PUT")
+	}
+	if got := namedLoggers["test-named-level-handler"].level.Level(); got != zapcore.ErrorLevel {
+		t.Fatalf("level after PUT = %v, want %v", got, zapcore.ErrorLevel)
+	}
+}
+
+func TestListLevelsIncludesRegisteredLoggers(t *testing.T) {
+	NewNamedLogger("test-list-levels", WithLogLevel(zapcore.WarnLevel))
+
+	req := httptest.NewRequest("GET", "/log/levels", nil)
+	rec := httptest.NewRecorder()
+	ListLevels(rec, req)
+
+	var levels map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &levels); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if levels["test-list-levels"] != "warn" {
+		t.Fatalf("levels[%q] = %q, want %q", "test-list-levels", levels["test-list-levels"], "warn")
+	}
+}
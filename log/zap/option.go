@@ -1,8 +1,25 @@
 package zaplog
 
 import (
-	"go.uber.org/zap/zapcore"
+	"os"
 	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaults for the sink ring buffers added by WithKafkaSink/WithLokiSink/
+// WithSyslogSink: how many records they hold before dropping the oldest,
+// how many they ship per flush, and how often they flush.
+const (
+	defaultSinkBufferSize    = 4096
+	defaultSinkBatchSize     = 256
+	defaultSinkFlushInterval = time.Second
+
+	// defaultRateLimitReportTick is how often WithRateLimit emits its
+	// "dropped N messages at level X" summary record.
+	defaultRateLimitReportTick = time.Second
 )
 
 // if you want to write log to file,
@@ -14,6 +31,39 @@ type logOptions struct {
 	fileRotationTime time.Duration
 	logLevel         zapcore.Level
 	development      bool
+
+	// traceCorrelation enables the WithContext/Ctx/DebugCtx/InfoCtx/ErrorCtx
+	// family: trace_id/span_id injection and span event/status recording.
+	traceCorrelation     bool
+	otelMinLevel         zapcore.Level
+	otelErrorStatusLevel zapcore.Level
+
+	// extraCores are tee'd alongside the file/stderr cores built by
+	// NewLogger: a caller-supplied WithCustomCore.
+	extraCores []zapcore.Core
+
+	// sinkFactories build the cores for WithKafkaSink/WithLokiSink/
+	// WithSyslogSink once NewLogger knows the final resolved log level,
+	// rather than capturing whatever o.logLevel happened to be at the
+	// point the Option ran. An Option's apply() runs in argument order, so
+	// building the enabler eagerly would make NewLogger(WithKafkaSink(...),
+	// WithLogLevel(...)) silently ship everything at the default level
+	// instead of the one just configured.
+	sinkFactories []func(enabler zapcore.LevelEnabler) zapcore.Core
+
+	sampling         SamplingConfig
+	perLevelSampling map[zapcore.Level]SamplingConfig
+
+	rateLimitPerSecond int
+	rateLimitBurst     int
+
+	addCaller     bool
+	callerSkip    int
+	addStacktrace zapcore.LevelEnabler
+	wrapCoreFunc  func(zapcore.Core) zapcore.Core
+	fields        []zap.Field
+	hooks         []func(zapcore.Entry) error
+	errorOutput   zapcore.WriteSyncer
 }
 
 var defaultLogOptions = logOptions{
@@ -24,6 +74,10 @@ var defaultLogOptions = logOptions{
 	fileRotateMaxAge: 0,
 	// 0 means the fileRotationTime will default set to 24 * time.Hour
 	fileRotationTime: 0,
+
+	traceCorrelation:     false,
+	otelMinLevel:         zapcore.WarnLevel,
+	otelErrorStatusLevel: zapcore.ErrorLevel,
 }
 
 type Option interface {
@@ -82,3 +136,151 @@ func Development() Option {
 		o.development = true
 	})
 }
+
+// AddCaller configures the logger to annotate each message with the
+// filename and line number of its call site.
+func AddCaller() Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.addCaller = true
+	})
+}
+
+// AddCallerSkip increases the number of callers skipped by caller
+// annotation, for wrappers that would otherwise report their own call
+// site instead of their caller's.
+func AddCallerSkip(skip int) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.callerSkip = skip
+	})
+}
+
+// AddStacktrace configures the logger to record a stack trace for every
+// message at or above the given level.
+func AddStacktrace(level zapcore.LevelEnabler) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.addStacktrace = level
+	})
+}
+
+// WithTraceCorrelation turns on trace_id/span_id correlation for the
+// WithContext/Ctx/DebugCtx/InfoCtx/ErrorCtx helpers: the active OpenTelemetry
+// span is read out of the context and its IDs are attached as fields. The
+// DebugCtx/InfoCtx/ErrorCtx helpers also record the message as a span event
+// once the level reaches otelMinLevel, and set the span status to Error once
+// the level reaches otelErrorStatusLevel. Loggers returned by WithContext/Ctx
+// attach the IDs only: they record no span events and set no span status.
+func WithTraceCorrelation() Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.traceCorrelation = true
+	})
+}
+
+// WithOtelMinLevel sets the minimum level at which a log record is also
+// recorded as a span event on the context's active span. Defaults to
+// zapcore.WarnLevel.
+func WithOtelMinLevel(level zapcore.Level) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.otelMinLevel = level
+	})
+}
+
+// WithOtelErrorStatusLevel sets the level at and above which the context's
+// active span has its status set to Error. Defaults to zapcore.ErrorLevel.
+func WithOtelErrorStatusLevel(level zapcore.Level) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.otelErrorStatusLevel = level
+	})
+}
+
+// WithKafkaSink tees the logger into a Kafka topic, in addition to its
+// file/stderr cores. Records are JSON, keyed by KUBE_PODNAME, and shipped
+// through a bounded ring buffer that drops the oldest record rather than
+// blocking the caller when brokers fall behind.
+func WithKafkaSink(topic string, brokers []string) Option {
+	return logOptionFunc(func(o *logOptions) {
+		writer, err := newKafkaSinkWriter(topic, brokers)
+		if err != nil {
+			panic(errors.Wrap(err, "error create kafka sink"))
+		}
+		o.sinkFactories = append(o.sinkFactories, func(enabler zapcore.LevelEnabler) zapcore.Core {
+			return newSinkCore(
+				"kafka", enabler, zapcore.NewJSONEncoder(NewEncoderConfig()),
+				writer, defaultSinkBufferSize, defaultSinkBatchSize, defaultSinkFlushInterval,
+			)
+		})
+	})
+}
+
+// WithLokiSink tees the logger into Loki's /loki/api/v1/push endpoint, in
+// addition to its file/stderr cores. Streams are labeled with serviceName,
+// KUBE_PODNAME, and level, and shipped through the same bounded ring
+// buffer as the other sinks.
+func WithLokiSink(pushURL string) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.sinkFactories = append(o.sinkFactories, func(enabler zapcore.LevelEnabler) zapcore.Core {
+			writer := newLokiSinkWriter(pushURL, o.serviceName, os.Getenv("KUBE_PODNAME"))
+			return newSinkCore(
+				"loki", enabler, zapcore.NewJSONEncoder(NewEncoderConfig()),
+				writer, defaultSinkBufferSize, defaultSinkBatchSize, defaultSinkFlushInterval,
+			)
+		})
+	})
+}
+
+// WithSyslogSink tees the logger into a syslog collector over network, in
+// addition to its file/stderr cores, speaking RFC5424 over the given
+// network ("udp" or "tcp").
+func WithSyslogSink(network, addr string) Option {
+	return logOptionFunc(func(o *logOptions) {
+		writer, err := newSyslogSinkWriter(network, addr, o.serviceName)
+		if err != nil {
+			panic(errors.Wrap(err, "error create syslog sink"))
+		}
+		o.sinkFactories = append(o.sinkFactories, func(enabler zapcore.LevelEnabler) zapcore.Core {
+			return newSinkCore(
+				"syslog", enabler, zapcore.NewJSONEncoder(NewEncoderConfig()),
+				writer, defaultSinkBufferSize, defaultSinkBatchSize, defaultSinkFlushInterval,
+			)
+		})
+	})
+}
+
+// WithCustomCore tees the logger into an arbitrary zapcore.Core, in
+// addition to its file/stderr cores. Use this for sinks not covered by
+// WithKafkaSink/WithLokiSink/WithSyslogSink.
+func WithCustomCore(core zapcore.Core) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.extraCores = append(o.extraCores, core)
+	})
+}
+
+// WithSampling wraps every core NewLogger builds with zap's message-hash
+// sampler: of the records sharing the same message within tick, only the
+// first first of them and every thereafter-th one after that get through.
+// This bounds log volume when something starts logging the same message
+// in a hot loop during an incident.
+func WithSampling(tick time.Duration, first, thereafter int) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.sampling = SamplingConfig{Tick: tick, First: first, Thereafter: thereafter}
+	})
+}
+
+// WithPerLevelSampling overrides WithSampling's configuration for specific
+// levels, so e.g. Error can be sampled more leniently than Info.
+func WithPerLevelSampling(perLevel map[zapcore.Level]SamplingConfig) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.perLevelSampling = perLevel
+	})
+}
+
+// WithRateLimit caps how many records per second NewLogger's cores accept,
+// per level, dropping the rest rather than blocking the caller. Because the
+// budget is tracked per level, a flood of Info records cannot starve Error
+// records. Once a second it emits a single summary record per level that
+// dropped anything ("dropped N messages at level X").
+func WithRateLimit(perSecond, burst int) Option {
+	return logOptionFunc(func(o *logOptions) {
+		o.rateLimitPerSecond = perSecond
+		o.rateLimitBurst = burst
+	})
+}
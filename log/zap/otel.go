@@ -0,0 +1,86 @@
+package zaplog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithContext returns the global logger enriched with the trace_id and
+// span_id of the span active in ctx, for correlating log records with
+// traces. It is a no-op (returns the plain logger) when WithTraceCorrelation
+// was not passed to InitLogger, or when ctx carries no valid span.
+//
+// The returned logger is meant to be chained directly (zaplog.Ctx(ctx).
+// Info(msg)), so it carries no extra caller skip beyond the plain logger's.
+func WithContext(ctx context.Context) *zap.Logger {
+	return withContext(ctx, otelLogger)
+}
+
+// Ctx is a short alias for WithContext.
+func Ctx(ctx context.Context) *zap.Logger {
+	return WithContext(ctx)
+}
+
+// ctxForHelpers is WithContext built off otelLoggerForHelpers instead of
+// otelLogger: DebugCtx/InfoCtx/ErrorCtx add one more call frame than direct
+// chaining does, so they need the extra AddCallerSkip(1) to still report
+// the user's call site rather than a line inside this package.
+func ctxForHelpers(ctx context.Context) *zap.Logger {
+	return withContext(ctx, otelLoggerForHelpers)
+}
+
+func withContext(ctx context.Context, base *zap.Logger) *zap.Logger {
+	if !traceCorrelationEnabled {
+		return base
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return base
+	}
+	return base.With(
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	)
+}
+
+// DebugCtx logs msg at DebugLevel, correlated with the span active in ctx.
+func DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ctxForHelpers(ctx).Debug(msg, fields...)
+	annotateSpan(ctx, zapcore.DebugLevel, msg)
+}
+
+// InfoCtx logs msg at InfoLevel, correlated with the span active in ctx.
+func InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ctxForHelpers(ctx).Info(msg, fields...)
+	annotateSpan(ctx, zapcore.InfoLevel, msg)
+}
+
+// ErrorCtx logs msg at ErrorLevel, correlated with the span active in ctx.
+func ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ctxForHelpers(ctx).Error(msg, fields...)
+	annotateSpan(ctx, zapcore.ErrorLevel, msg)
+}
+
+// annotateSpan records msg as an event on the span active in ctx once lvl
+// reaches otelMinLevel, and sets the span status to Error once lvl reaches
+// otelErrorStatusLevel.
+func annotateSpan(ctx context.Context, lvl zapcore.Level, msg string) {
+	if !traceCorrelationEnabled || (lvl < otelMinLevel && lvl < otelErrorStatusLevel) {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return
+	}
+	if lvl >= otelMinLevel {
+		span.AddEvent(msg, trace.WithAttributes(attribute.String("level", lvl.String())))
+	}
+	if lvl >= otelErrorStatusLevel {
+		span.SetStatus(codes.Error, msg)
+	}
+}
@@ -0,0 +1,74 @@
+package zaplog
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanContextWithValidIDs(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	if err != nil {
+		t.Fatalf("TraceIDFromHex: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	if err != nil {
+		t.Fatalf("SpanIDFromHex: %v", err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestWithContextNoTraceCorrelationReturnsPlainLogger(t *testing.T) {
+	defer func(enabled bool) { traceCorrelationEnabled = enabled }(traceCorrelationEnabled)
+	traceCorrelationEnabled = false
+
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContextWithValidIDs(t))
+	if got := WithContext(ctx); got != otelLogger {
+		t.Errorf("WithContext() = %p, want the plain otelLogger %p", got, otelLogger)
+	}
+}
+
+func TestWithContextNoSpanReturnsPlainLogger(t *testing.T) {
+	defer func(enabled bool) { traceCorrelationEnabled = enabled }(traceCorrelationEnabled)
+	traceCorrelationEnabled = true
+
+	if got := WithContext(context.Background()); got != otelLogger {
+		t.Errorf("WithContext() = %p, want the plain otelLogger %p", got, otelLogger)
+	}
+}
+
+func TestWithContextValidSpanReturnsEnrichedLogger(t *testing.T) {
+	defer func(enabled bool) { traceCorrelationEnabled = enabled }(traceCorrelationEnabled)
+	traceCorrelationEnabled = true
+
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContextWithValidIDs(t))
+	if got := WithContext(ctx); got == otelLogger {
+		t.Error("WithContext() returned the base logger unchanged, want one with trace_id/span_id fields attached")
+	}
+}
+
+// TestCtxForHelpersUsesHelperSkip checks that DebugCtx/InfoCtx/ErrorCtx's
+// internal logger is built off otelLoggerForHelpers, not otelLogger — the
+// two must stay distinct or the Ctx()-chaining callers and the *Ctx helpers
+// can't both report the correct call site (the bug this series fixed in
+// [fengberlin/libgo#chunk0-1]).
+func TestCtxForHelpersUsesHelperSkip(t *testing.T) {
+	defer func(enabled bool) { traceCorrelationEnabled = enabled }(traceCorrelationEnabled)
+	traceCorrelationEnabled = false
+
+	if got := ctxForHelpers(context.Background()); got != otelLoggerForHelpers {
+		t.Errorf("ctxForHelpers() = %p, want otelLoggerForHelpers %p", got, otelLoggerForHelpers)
+	}
+	if got := WithContext(context.Background()); got != otelLogger {
+		t.Errorf("WithContext() = %p, want otelLogger %p", got, otelLogger)
+	}
+	if otelLogger == otelLoggerForHelpers {
+		t.Fatal("otelLogger and otelLoggerForHelpers must not be the same logger")
+	}
+}
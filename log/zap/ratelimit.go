@@ -0,0 +1,167 @@
+package zaplog
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// rateLimitDropped counts records dropped by WithRateLimit, keyed by level.
+var rateLimitDropped = expvar.NewMap("zaplog_ratelimit_dropped")
+
+// tokenBucket is a per-level token bucket: perSecond tokens refill
+// continuously up to burst, and allow reports whether a token was
+// available for the record being logged right now.
+type tokenBucket struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	dropped int64
+	counter *expvar.Int
+}
+
+func newTokenBucket(level zapcore.Level, perSecond, burst int) *tokenBucket {
+	counter := new(expvar.Int)
+	rateLimitDropped.Set(level.String(), counter)
+	return &tokenBucket{
+		rate:    float64(perSecond),
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+		counter: counter,
+	}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		b.dropped++
+		b.counter.Add(1)
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// takeDropped returns the number of records dropped since the last call and
+// resets the count.
+func (b *tokenBucket) takeDropped() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.dropped
+	b.dropped = 0
+	return n
+}
+
+// rateLimitCore drops records once the token bucket for their level is
+// empty, rather than blocking the caller or queuing. Because each level has
+// its own bucket, a flood of Info records cannot starve Error records. Once
+// per tick it writes a single summary record for every level that dropped
+// anything.
+type rateLimitCore struct {
+	zapcore.Core
+	mu        sync.Mutex
+	buckets   map[zapcore.Level]*tokenBucket
+	perSec    int
+	burst     int
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce *sync.Once
+}
+
+func newRateLimitCore(core zapcore.Core, perSecond, burst int, tick time.Duration) *rateLimitCore {
+	c := &rateLimitCore{
+		Core:      core,
+		buckets:   make(map[zapcore.Level]*tokenBucket),
+		perSec:    perSecond,
+		burst:     burst,
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		closeOnce: new(sync.Once),
+	}
+	go c.reportDropped(tick)
+	return c
+}
+
+func (c *rateLimitCore) bucketFor(lvl zapcore.Level) *tokenBucket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.buckets[lvl]
+	if !ok {
+		b = newTokenBucket(lvl, c.perSec, c.burst)
+		c.buckets[lvl] = b
+	}
+	return b
+}
+
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(ent.Level) || !c.bucketFor(ent.Level).allow() {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{
+		Core:      c.Core.With(fields),
+		buckets:   c.buckets,
+		perSec:    c.perSec,
+		burst:     c.burst,
+		stop:      c.stop,
+		stopped:   c.stopped,
+		closeOnce: c.closeOnce,
+	}
+}
+
+func (c *rateLimitCore) reportDropped(tick time.Duration) {
+	defer close(c.stopped)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			buckets := make(map[zapcore.Level]*tokenBucket, len(c.buckets))
+			for lvl, b := range c.buckets {
+				buckets[lvl] = b
+			}
+			c.mu.Unlock()
+
+			for lvl, b := range buckets {
+				if n := b.takeDropped(); n > 0 {
+					ent := zapcore.Entry{Level: lvl, Time: time.Now(), Message: fmt.Sprintf("dropped %d messages at level %s", n, lvl)}
+					_ = c.Core.Write(ent, []zapcore.Field{zap.Int64("dropped", n), zap.String("level", lvl.String())})
+				}
+			}
+		}
+	}
+}
+
+// Sync stops the background reportDropped goroutine (idempotently, so it's
+// safe to call from any of the cores returned by With, which all share the
+// same shutdown signal) before delegating to the wrapped core's Sync, the
+// same teardown sinkCore.Sync uses for its own drain goroutine.
+func (c *rateLimitCore) Sync() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		<-c.stopped
+	})
+	return c.Core.Sync()
+}
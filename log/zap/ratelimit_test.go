@@ -0,0 +1,131 @@
+package zaplog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTokenBucketAllowsUpToBurstThenDrops(t *testing.T) {
+	b := newTokenBucket(zapcore.InfoLevel, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		if !b.allow() {
+			t.Fatalf("allow() #%d = false, want true (within burst)", i)
+		}
+	}
+	if b.allow() {
+		t.Fatal("allow() = true after burst exhausted, want false")
+	}
+	if got := b.takeDropped(); got != 1 {
+		t.Fatalf("takeDropped() = %d, want 1", got)
+	}
+	if got := b.takeDropped(); got != 0 {
+		t.Fatalf("takeDropped() after reset = %d, want 0", got)
+	}
+}
+
+func TestTokenBucketRefillsOverTime(t *testing.T) {
+	b := newTokenBucket(zapcore.InfoLevel, 1000, 1)
+
+	if !b.allow() {
+		t.Fatal("allow() = false on first call, want true")
+	}
+	if b.allow() {
+		t.Fatal("allow() = true immediately after exhausting burst, want false")
+	}
+
+	// Backdate last so the next allow() sees enough elapsed time to have
+	// refilled at least one token, without a real sleep.
+	b.mu.Lock()
+	b.last = b.last.Add(-time.Second)
+	b.mu.Unlock()
+
+	if !b.allow() {
+		t.Fatal("allow() = false after refill window, want true")
+	}
+}
+
+// TestTokenBucketConcurrentAllow exercises allow() from many goroutines at
+// once (run with -race): every call must be serialized by the bucket's
+// mutex, and allowed+dropped must account for every call made.
+func TestTokenBucketConcurrentAllow(t *testing.T) {
+	b := newTokenBucket(zapcore.InfoLevel, 0, 50)
+
+	const callers = 10
+	const perCaller = 20
+	var wg sync.WaitGroup
+	var allowed int64
+	var mu sync.Mutex
+
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perCaller; j++ {
+				if b.allow() {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	dropped := b.takeDropped()
+	if got := allowed + dropped; got != callers*perCaller {
+		t.Fatalf("allowed=%d dropped=%d, want total=%d", allowed, dropped, callers*perCaller)
+	}
+	if allowed != 50 {
+		t.Fatalf("allowed = %d, want exactly burst=50 (rate=0 means no refill)", allowed)
+	}
+}
+
+// TestRateLimitCoreSyncStopsReportDropped checks that Sync tears down the
+// background reportDropped goroutine, and that calling it more than once
+// (as the package's own Sync() helper does, via logger.Sync() and
+// sugaredLogger.Sync() sharing the same core chain) doesn't hang or panic.
+func TestRateLimitCoreSyncStopsReportDropped(t *testing.T) {
+	c := newRateLimitCore(zapcore.NewNopCore(), 10, 10, time.Millisecond)
+
+	if err := c.Sync(); err != nil {
+		t.Fatalf("Sync() = %v, want nil", err)
+	}
+	if err := c.Sync(); err != nil {
+		t.Fatalf("second Sync() = %v, want nil", err)
+	}
+
+	select {
+	case <-c.stopped:
+	default:
+		t.Fatal("reportDropped goroutine still running after Sync()")
+	}
+}
+
+func TestLevelFilterCorePassesOnlyConfiguredLevel(t *testing.T) {
+	c := &levelFilterCore{only: zapcore.ErrorLevel, filterOnly: true}
+
+	if !c.passes(zapcore.ErrorLevel) {
+		t.Error("passes(Error) = false, want true")
+	}
+	if c.passes(zapcore.InfoLevel) {
+		t.Error("passes(Info) = true, want false")
+	}
+}
+
+func TestLevelFilterCorePassesAllExceptConfigured(t *testing.T) {
+	c := &levelFilterCore{except: map[zapcore.Level]bool{zapcore.ErrorLevel: true}}
+
+	if c.passes(zapcore.ErrorLevel) {
+		t.Error("passes(Error) = true, want false")
+	}
+	if !c.passes(zapcore.InfoLevel) {
+		t.Error("passes(Info) = false, want true")
+	}
+	if !c.passes(zapcore.WarnLevel) {
+		t.Error("passes(Warn) = false, want true")
+	}
+}
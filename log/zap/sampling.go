@@ -0,0 +1,67 @@
+package zaplog
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig mirrors zapcore.NewSamplerWithOptions' parameters for a
+// single level, letting WithPerLevelSampling tune each level independently.
+type SamplingConfig struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+// wrapWithSampling tees core through zap's message-hash sampler using cfg
+// for every level, except that any level named in perLevel is sampled
+// independently using its own SamplingConfig. When cfg.Tick is zero, levels
+// not named in perLevel pass through unsampled.
+func wrapWithSampling(core zapcore.Core, cfg SamplingConfig, perLevel map[zapcore.Level]SamplingConfig) zapcore.Core {
+	if len(perLevel) == 0 {
+		return zapcore.NewSamplerWithOptions(core, cfg.Tick, cfg.First, cfg.Thereafter)
+	}
+
+	configured := make(map[zapcore.Level]bool, len(perLevel))
+	cores := make([]zapcore.Core, 0, len(perLevel)+1)
+	for lvl, lvlCfg := range perLevel {
+		configured[lvl] = true
+		cores = append(cores, zapcore.NewSamplerWithOptions(
+			&levelFilterCore{Core: core, only: lvl, filterOnly: true}, lvlCfg.Tick, lvlCfg.First, lvlCfg.Thereafter,
+		))
+	}
+	var rest zapcore.Core = &levelFilterCore{Core: core, except: configured}
+	if cfg.Tick > 0 {
+		rest = zapcore.NewSamplerWithOptions(rest, cfg.Tick, cfg.First, cfg.Thereafter)
+	}
+	cores = append(cores, rest)
+	return zapcore.NewTee(cores...)
+}
+
+// levelFilterCore lets through only records at a single level (filterOnly),
+// or, when except is set instead, any level not in except.
+type levelFilterCore struct {
+	zapcore.Core
+	only       zapcore.Level
+	filterOnly bool
+	except     map[zapcore.Level]bool
+}
+
+func (c *levelFilterCore) passes(lvl zapcore.Level) bool {
+	if c.filterOnly {
+		return lvl == c.only
+	}
+	return !c.except[lvl]
+}
+
+func (c *levelFilterCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.passes(ent.Level) && c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *levelFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFilterCore{Core: c.Core.With(fields), only: c.only, filterOnly: c.filterOnly, except: c.except}
+}
@@ -0,0 +1,223 @@
+package zaplog
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sinkRecord is one already-encoded log record along with the level and
+// time it was logged at, so a sinkWriter can make per-record decisions
+// (syslog severity, Loki stream labels) instead of treating a whole batch
+// as one level.
+type sinkRecord struct {
+	Level zapcore.Level
+	Time  time.Time
+	Data  []byte
+}
+
+// sinkDroppedRecords counts records dropped by sink ring buffers on
+// overflow, keyed by sink name (e.g. "kafka", "loki", "syslog").
+var sinkDroppedRecords = expvar.NewMap("zaplog_sink_dropped_records")
+
+func droppedCounter(name string) *expvar.Int {
+	if v := sinkDroppedRecords.Get(name); v != nil {
+		return v.(*expvar.Int)
+	}
+	counter := new(expvar.Int)
+	sinkDroppedRecords.Set(name, counter)
+	return counter
+}
+
+// sinkWriter is the surface a network sink backend must implement: ship a
+// batch of records out, and release any held resources.
+type sinkWriter interface {
+	WriteBatch(records []sinkRecord) error
+	Close() error
+}
+
+// ringBuffer is a bounded queue of encoded log records. Multiple producer
+// goroutines may push concurrently; a single background goroutine (or a
+// synchronous Sync) drains it with pop. It never blocks the caller: once
+// full, push drops the oldest queued record and counts it rather than
+// overwriting a slot a concurrent pop might be reading, which a hand-rolled
+// atomic-indexed ring risks (a producer can reserve a slot before writing
+// into it, so a concurrent pop sees a stale/zero entry and silently loses
+// it). The buffered channel gives the same bounded, non-blocking semantics
+// with that correctness for free.
+type ringBuffer struct {
+	records chan sinkRecord
+	dropped *expvar.Int
+}
+
+func newRingBuffer(name string, capacity int) *ringBuffer {
+	return &ringBuffer{
+		records: make(chan sinkRecord, capacity),
+		dropped: droppedCounter(name),
+	}
+}
+
+// push enqueues record, dropping the oldest queued record (and incrementing
+// the sink's drop counter) if the buffer is already full.
+func (r *ringBuffer) push(record sinkRecord) {
+	select {
+	case r.records <- record:
+		return
+	default:
+	}
+	// Full: make room by dropping the oldest record, then enqueue. If we
+	// lose the race for the freed slot to another producer, drop the new
+	// record instead rather than blocking.
+	select {
+	case <-r.records:
+		r.dropped.Add(1)
+	default:
+	}
+	select {
+	case r.records <- record:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// pop dequeues the oldest record, or reports ok=false if the buffer is
+// empty.
+func (r *ringBuffer) pop() (record sinkRecord, ok bool) {
+	select {
+	case record = <-r.records:
+		return record, true
+	default:
+		return sinkRecord{}, false
+	}
+}
+
+// sinkCore is a zapcore.Core that encodes records into a ringBuffer, which
+// a background goroutine drains in batches to a sinkWriter. It never blocks
+// the calling goroutine.
+type sinkCore struct {
+	zapcore.LevelEnabler
+	enc       zapcore.Encoder
+	ring      *ringBuffer
+	writer    sinkWriter
+	batchSize int
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce *sync.Once
+}
+
+func newSinkCore(name string, enabler zapcore.LevelEnabler, enc zapcore.Encoder, writer sinkWriter, bufferSize, batchSize int, flushInterval time.Duration) *sinkCore {
+	c := &sinkCore{
+		LevelEnabler: enabler,
+		enc:          enc,
+		ring:         newRingBuffer(name, bufferSize),
+		writer:       writer,
+		batchSize:    batchSize,
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		closeOnce:    new(sync.Once),
+	}
+	go c.drain(flushInterval)
+	return c
+}
+
+func (c *sinkCore) drain(interval time.Duration) {
+	defer close(c.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.flushBatch()
+		}
+	}
+}
+
+func (c *sinkCore) flushBatch() error {
+	batch := make([]sinkRecord, 0, c.batchSize)
+	for len(batch) < c.batchSize {
+		rec, ok := c.ring.pop()
+		if !ok {
+			break
+		}
+		batch = append(batch, rec)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return c.writer.WriteBatch(batch)
+}
+
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone)
+	}
+	return &sinkCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          clone,
+		ring:         c.ring,
+		writer:       c.writer,
+		batchSize:    c.batchSize,
+		stop:         c.stop,
+		stopped:      c.stopped,
+		closeOnce:    c.closeOnce,
+	}
+}
+
+func (c *sinkCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *sinkCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	data := append([]byte(nil), buf.Bytes()...)
+	buf.Free()
+	c.ring.push(sinkRecord{Level: ent.Level, Time: ent.Time, Data: data})
+	return nil
+}
+
+// Sync stops the background drain goroutine (so it can no longer race this
+// call over the ring buffer), flushes whatever is still queued
+// synchronously, and closes the underlying sinkWriter, propagating the
+// first error encountered. It is safe to call more than once, and from any
+// of the cores returned by With, since they all share the same ring,
+// writer, and shutdown signal — the whole body runs behind closeOnce, so a
+// second call is a no-op rather than closing writer again.
+func (c *sinkCore) Sync() error {
+	var firstErr error
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		<-c.stopped
+
+		for {
+			batch := make([]sinkRecord, 0, c.batchSize)
+			for len(batch) < c.batchSize {
+				rec, ok := c.ring.pop()
+				if !ok {
+					break
+				}
+				batch = append(batch, rec)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			if err := c.writer.WriteBatch(batch); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if err := c.writer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	})
+	return firstErr
+}
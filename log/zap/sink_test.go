@@ -0,0 +1,123 @@
+package zaplog
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// TestRingBufferConcurrentPush pushes from many goroutines at once (run
+// with -race) and checks that every record is accounted for as either
+// popped or counted in dropped — the earlier atomic-indexed implementation
+// could silently lose a record that a concurrent pop read mid-write.
+func TestRingBufferConcurrentPush(t *testing.T) {
+	rb := newRingBuffer(t.Name(), 64)
+
+	const producers = 8
+	const perProducer = 200
+	total := producers * perProducer
+
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				rb.push(sinkRecord{Level: zapcore.InfoLevel, Data: []byte("x")})
+			}
+		}()
+	}
+	wg.Wait()
+
+	popped := 0
+	for {
+		if _, ok := rb.pop(); !ok {
+			break
+		}
+		popped++
+	}
+
+	if got := popped + int(rb.dropped.Value()); got != total {
+		t.Fatalf("lost records: popped=%d dropped=%d want total=%d", popped, rb.dropped.Value(), total)
+	}
+}
+
+// TestRingBufferConcurrentPushAndDrain runs pushes and a concurrent drainer
+// together, the same way sinkCore's background goroutine races a producer.
+func TestRingBufferConcurrentPushAndDrain(t *testing.T) {
+	rb := newRingBuffer(t.Name(), 16)
+
+	const producers = 4
+	const perProducer = 500
+	total := producers * perProducer
+
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				rb.push(sinkRecord{Level: zapcore.InfoLevel, Data: []byte("x")})
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	poppedCh := make(chan int64)
+	go func() {
+		var popped int64
+		for {
+			if _, ok := rb.pop(); ok {
+				popped++
+				continue
+			}
+			select {
+			case <-stop:
+				poppedCh <- popped
+				return
+			default:
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	popped := <-poppedCh
+
+	// Producers are done, so draining what's left here is single-threaded.
+	for {
+		if _, ok := rb.pop(); !ok {
+			break
+		}
+		popped++
+	}
+
+	if got := popped + rb.dropped.Value(); got != int64(total) {
+		t.Fatalf("lost records: popped=%d dropped=%d want total=%d", popped, rb.dropped.Value(), total)
+	}
+}
+
+func TestRingBufferDropsOldestOnOverflow(t *testing.T) {
+	rb := newRingBuffer(t.Name(), 2)
+
+	rb.push(sinkRecord{Data: []byte("1")})
+	rb.push(sinkRecord{Data: []byte("2")})
+	rb.push(sinkRecord{Data: []byte("3")}) // buffer full: drops "1"
+
+	if got := rb.dropped.Value(); got != 1 {
+		t.Fatalf("dropped = %d, want 1", got)
+	}
+
+	rec, ok := rb.pop()
+	if !ok || string(rec.Data) != "2" {
+		t.Fatalf("pop() = %q, %v, want %q, true", rec.Data, ok, "2")
+	}
+	rec, ok = rb.pop()
+	if !ok || string(rec.Data) != "3" {
+		t.Fatalf("pop() = %q, %v, want %q, true", rec.Data, ok, "3")
+	}
+	if _, ok := rb.pop(); ok {
+		t.Fatal("pop() on empty buffer returned ok=true")
+	}
+}
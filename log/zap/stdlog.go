@@ -0,0 +1,27 @@
+package zaplog
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedirectStdLog redirects output from the standard library's log package
+// to the global logger, and returns a function to restore the original
+// behavior. Useful for third-party libraries that log through the stdlib
+// log package instead of taking a logger of their own.
+func RedirectStdLog() func() {
+	return zap.RedirectStdLog(logger)
+}
+
+// StdLogger returns a stdlib *log.Logger that writes every line at level,
+// for third-party libraries that accept only a *log.Logger.
+func StdLogger(level zapcore.Level) *log.Logger {
+	stdLogger, err := zap.NewStdLogAt(logger, level)
+	if err != nil {
+		panic(errors.Wrap(err, "error create std logger"))
+	}
+	return stdLogger
+}
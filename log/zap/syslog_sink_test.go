@@ -0,0 +1,27 @@
+package zaplog
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSyslogSeverityMapsLevelsToRFC5424Severity(t *testing.T) {
+	cases := []struct {
+		level zapcore.Level
+		want  int
+	}{
+		{zapcore.DebugLevel, syslogSeverityDebug},
+		{zapcore.InfoLevel, syslogSeverityInfo},
+		{zapcore.WarnLevel, syslogSeverityWarning},
+		{zapcore.ErrorLevel, syslogSeverityError},
+		{zapcore.DPanicLevel, syslogSeverityCrit},
+		{zapcore.PanicLevel, syslogSeverityCrit},
+		{zapcore.FatalLevel, syslogSeverityEmerg},
+	}
+	for _, c := range cases {
+		if got := syslogSeverity(c.level); got != c.want {
+			t.Errorf("syslogSeverity(%s) = %d, want %d", c.level, got, c.want)
+		}
+	}
+}